@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"math"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// AdapterSink receives decoded log entries from an adapter core. Implementations
+// forward them to a third-party logger such as hclog or logr, mapping level to
+// whatever severity concept the target logger has.
+type AdapterSink interface {
+	Log(level zapcore.Level, msg string, keyvals ...interface{})
+}
+
+// NewAdapterCore returns a zapcore.Core that decodes every field by its
+// zapcore.FieldType and forwards (msg, key/value pairs...) to target, following the
+// pattern go-ethereum uses to bridge zap into hclog/logr style loggers. This lets
+// WunderGraph host plugin or operator code while unifying output through this
+// package's encoder, base fields and request-ID conventions upstream of target.
+func NewAdapterCore(target AdapterSink) zapcore.Core {
+	return &adapterCore{LevelEnabler: zapcore.DebugLevel, target: target}
+}
+
+type adapterCore struct {
+	zapcore.LevelEnabler
+	fields []zapcore.Field
+	target AdapterSink
+}
+
+func (c *adapterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &adapterCore{
+		LevelEnabler: c.LevelEnabler,
+		fields:       append(append([]zapcore.Field(nil), c.fields...), fields...),
+		target:       c.target,
+	}
+}
+
+func (c *adapterCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *adapterCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	all := append(append([]zapcore.Field(nil), c.fields...), fields...)
+
+	keyvals := make([]interface{}, 0, len(all)*2)
+	for _, f := range all {
+		keyvals = append(keyvals, f.Key, decodeField(f))
+	}
+
+	c.target.Log(entry.Level, entry.Message, keyvals...)
+	return nil
+}
+
+func (c *adapterCore) Sync() error {
+	return nil
+}
+
+// decodeField converts a zapcore.Field back into a plain Go value based on its
+// FieldType, the same way go-ethereum's zap adapter does.
+func decodeField(f zapcore.Field) interface{} {
+	switch f.Type {
+	case zapcore.BoolType:
+		return f.Integer == 1
+	case zapcore.DurationType:
+		return time.Duration(f.Integer)
+	case zapcore.Float64Type:
+		return math.Float64frombits(uint64(f.Integer))
+	case zapcore.Float32Type:
+		return math.Float32frombits(uint32(f.Integer))
+	case zapcore.StringType:
+		return f.String
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return err
+		}
+		return f.Interface
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return f.Integer
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.UintptrType:
+		return uint64(f.Integer)
+	default:
+		return f.Interface
+	}
+}
@@ -0,0 +1,29 @@
+package logging
+
+import (
+	"github.com/hashicorp/go-hclog"
+	"go.uber.org/zap/zapcore"
+)
+
+type hclogSink struct {
+	logger hclog.Logger
+}
+
+// NewHCLogAdapterSink returns an AdapterSink that forwards to an hclog.Logger, for
+// hosting HashiCorp plugin code through this package's logging pipeline.
+func NewHCLogAdapterSink(logger hclog.Logger) AdapterSink {
+	return &hclogSink{logger: logger}
+}
+
+func (s *hclogSink) Log(level zapcore.Level, msg string, keyvals ...interface{}) {
+	switch {
+	case level >= zapcore.ErrorLevel:
+		s.logger.Error(msg, keyvals...)
+	case level >= zapcore.WarnLevel:
+		s.logger.Warn(msg, keyvals...)
+	case level >= zapcore.InfoLevel:
+		s.logger.Info(msg, keyvals...)
+	default:
+		s.logger.Debug(msg, keyvals...)
+	}
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+)
+
+type logrSink struct {
+	logger logr.Logger
+}
+
+// NewLogrAdapterSink returns an AdapterSink that forwards to a logr.Logger, for
+// hosting controller-runtime operator code through this package's logging pipeline.
+func NewLogrAdapterSink(logger logr.Logger) AdapterSink {
+	return &logrSink{logger: logger}
+}
+
+func (s *logrSink) Log(level zapcore.Level, msg string, keyvals ...interface{}) {
+	if level >= zapcore.ErrorLevel {
+		s.logger.Error(errorFromKeyvals(keyvals), msg, keyvals...)
+		return
+	}
+	s.logger.Info(msg, keyvals...)
+}
+
+// errorFromKeyvals returns the first value in keyvals that implements error, since
+// logr.Logger.Error requires one explicitly while zap only carries it as a field.
+func errorFromKeyvals(keyvals []interface{}) error {
+	for _, v := range keyvals {
+		if err, ok := v.(error); ok {
+			return err
+		}
+	}
+	return nil
+}
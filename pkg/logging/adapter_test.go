@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDecodeField(t *testing.T) {
+	sampleErr := errors.New("boom")
+
+	cases := []struct {
+		name  string
+		field zapcore.Field
+		want  interface{}
+	}{
+		{"bool true", zap.Bool("b", true), true},
+		{"bool false", zap.Bool("b", false), false},
+		{"duration", zap.Duration("d", 2 * time.Second), 2 * time.Second},
+		{"float64", zap.Float64("f64", 3.5), 3.5},
+		{"float32", zap.Float32("f32", float32(1.5)), float32(1.5)},
+		{"string", zap.String("s", "hello"), "hello"},
+		{"error", zap.Error(sampleErr), error(sampleErr)},
+		{"int64", zap.Int64("i64", -7), int64(-7)},
+		{"int32", zap.Int32("i32", -7), int64(-7)},
+		{"uint64", zap.Uint64("u64", math.MaxUint64), uint64(math.MaxUint64)},
+		{"uint32", zap.Uint32("u32", math.MaxUint32), uint64(math.MaxUint32)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := decodeField(c.field); got != c.want {
+				t.Fatalf("decodeField(%+v) = %#v, want %#v", c.field, got, c.want)
+			}
+		})
+	}
+}
+
+type recordingAdapterSink struct {
+	level   zapcore.Level
+	msg     string
+	keyvals []interface{}
+}
+
+func (s *recordingAdapterSink) Log(level zapcore.Level, msg string, keyvals ...interface{}) {
+	s.level = level
+	s.msg = msg
+	s.keyvals = keyvals
+}
+
+func TestAdapterCoreForwardsDecodedFields(t *testing.T) {
+	sink := &recordingAdapterSink{}
+	logger := zap.New(NewAdapterCore(sink))
+
+	logger.Warn("something happened", zap.Int("attempt", 3), zap.String("op", "retry"))
+
+	if sink.level != zapcore.WarnLevel {
+		t.Fatalf("level = %v, want %v", sink.level, zapcore.WarnLevel)
+	}
+	if sink.msg != "something happened" {
+		t.Fatalf("msg = %q, want %q", sink.msg, "something happened")
+	}
+
+	want := map[string]interface{}{"attempt": int64(3), "op": "retry"}
+	if len(sink.keyvals) != len(want)*2 {
+		t.Fatalf("keyvals = %v, want %d entries", sink.keyvals, len(want)*2)
+	}
+	for i := 0; i < len(sink.keyvals); i += 2 {
+		key := sink.keyvals[i].(string)
+		if got, wantVal := sink.keyvals[i+1], want[key]; got != wantVal {
+			t.Fatalf("keyvals[%q] = %#v, want %#v", key, got, wantVal)
+		}
+	}
+}
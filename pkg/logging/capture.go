@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"encoding/json"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// CaptureRegistry routes log entries tagged with a request ID (see WithRequestID) to
+// a per-request buffer, so the entries logged during a single GraphQL operation can
+// be collected and returned as debug metadata when the caller sends x-wg-debug: true.
+type CaptureRegistry struct {
+	mu       sync.Mutex
+	captures map[string]*requestCapture
+}
+
+type requestCapture struct {
+	mu      sync.Mutex
+	entries []json.RawMessage
+}
+
+// NewCaptureRegistry returns an empty CaptureRegistry.
+func NewCaptureRegistry() *CaptureRegistry {
+	return &CaptureRegistry{captures: make(map[string]*requestCapture)}
+}
+
+// Begin starts capturing entries logged with the given request ID.
+func (r *CaptureRegistry) Begin(requestID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.captures[requestID] = &requestCapture{}
+}
+
+// End stops capturing for the given request ID and returns everything that was
+// logged for it, oldest first.
+func (r *CaptureRegistry) End(requestID string) []json.RawMessage {
+	r.mu.Lock()
+	c, ok := r.captures[requestID]
+	delete(r.captures, requestID)
+	r.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries
+}
+
+func (r *CaptureRegistry) append(requestID string, line []byte) {
+	r.mu.Lock()
+	c, ok := r.captures[requestID]
+	r.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	c.entries = append(c.entries, append(json.RawMessage(nil), line...))
+	c.mu.Unlock()
+}
+
+// Core returns a zapcore.Core that feeds this registry. Attach it to a
+// LockedMultiCore for the lifetime of the process; Begin/End scope the capture to a
+// single request.
+func (r *CaptureRegistry) Core(level zapcore.LevelEnabler, encoder zapcore.Encoder) zapcore.Core {
+	return &captureCore{LevelEnabler: level, encoder: encoder, registry: r}
+}
+
+type captureCore struct {
+	zapcore.LevelEnabler
+	encoder  zapcore.Encoder
+	fields   []zapcore.Field
+	registry *CaptureRegistry
+}
+
+func (c *captureCore) With(fields []zapcore.Field) zapcore.Core {
+	return &captureCore{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      c.encoder,
+		fields:       append(append([]zapcore.Field(nil), c.fields...), fields...),
+		registry:     c.registry,
+	}
+}
+
+func (c *captureCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *captureCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	all := append(append([]zapcore.Field(nil), c.fields...), fields...)
+
+	requestID := requestIDFromFields(all)
+	if requestID == "" {
+		return nil
+	}
+
+	line, err := c.encoder.EncodeEntry(entry, all)
+	if err != nil {
+		return err
+	}
+	c.registry.append(requestID, line.Bytes())
+	line.Free()
+	return nil
+}
+
+func (c *captureCore) Sync() error {
+	return nil
+}
+
+func requestIDFromFields(fields []zapcore.Field) string {
+	for _, f := range fields {
+		if f.Key == requestIDField && f.Type == zapcore.StringType {
+			return f.String
+		}
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestCaptureRegistryBeginEndScoping(t *testing.T) {
+	registry := NewCaptureRegistry()
+	logger := zap.New(registry.Core(zap.DebugLevel, zapJsonEncoder()))
+
+	registry.Begin("req-1")
+	logger.Info("tracked", WithRequestID("req-1"))
+
+	entries := registry.End("req-1")
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 captured entry for req-1, got %d", len(entries))
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(entries[0], &decoded); err != nil {
+		t.Fatalf("decode captured entry: %v", err)
+	}
+	if decoded[logMessageKey] != "tracked" {
+		t.Fatalf("captured entry %s = %v, want %q", logMessageKey, decoded[logMessageKey], "tracked")
+	}
+
+	// End is meant to be safe to call once the request has finished; a second call
+	// must not resurrect the same entries.
+	if got := registry.End("req-1"); got != nil {
+		t.Fatalf("expected second End for req-1 to return nil, got %v", got)
+	}
+}
+
+// TestCaptureRegistryDropsUntrackedRequestID covers the path where an entry carries a
+// request ID that Begin was never called for: it must be silently dropped rather than
+// accumulating forever in a capture nothing will ever End.
+func TestCaptureRegistryDropsUntrackedRequestID(t *testing.T) {
+	registry := NewCaptureRegistry()
+	logger := zap.New(registry.Core(zap.DebugLevel, zapJsonEncoder()))
+
+	logger.Info("orphan", WithRequestID("never-started"))
+
+	if got := registry.End("never-started"); got != nil {
+		t.Fatalf("expected no entries for a request ID that was never Begin'd, got %v", got)
+	}
+}
+
+func TestCaptureRegistryIgnoresEntriesWithoutRequestID(t *testing.T) {
+	registry := NewCaptureRegistry()
+	logger := zap.New(registry.Core(zap.DebugLevel, zapJsonEncoder()))
+
+	registry.Begin("req-1")
+	logger.Info("missing reqId entirely")
+	logger.Info("tracked", WithRequestID("req-1"))
+
+	entries := registry.End("req-1")
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 captured entry, got %d", len(entries))
+	}
+}
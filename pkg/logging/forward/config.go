@@ -0,0 +1,101 @@
+package forward
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wundergraph/wundergraph/pkg/logging"
+)
+
+// ForwardingConfig is the JSON shape expected under the "logging.forwarding" key of
+// wundergraph.config.json. It lets a cloud deployment ship logs to a broker without a
+// sidecar log shipper: set Kafka and/or OTLP to forward to either or both.
+type ForwardingConfig struct {
+	// Level is the minimum level forwarded, e.g. "info". Defaults to zapcore.InfoLevel.
+	Level string `json:"level,omitempty"`
+
+	Kafka *KafkaForwardingConfig `json:"kafka,omitempty"`
+	OTLP  *OTLPForwardingConfig  `json:"otlp,omitempty"`
+}
+
+// bufferingConfig is the JSON-tagged shape shared by every sink's buffering and
+// batching knobs, embedded into each backend's forwarding config.
+type bufferingConfig struct {
+	// BufferSize is the number of encoded entries held in memory awaiting flush.
+	BufferSize int `json:"bufferSize,omitempty"`
+	// DropPolicy is "dropOldest" (default) or "block".
+	DropPolicy string `json:"dropPolicy,omitempty"`
+	// BlockTimeoutMS is only used when DropPolicy is "block".
+	BlockTimeoutMS int `json:"blockTimeoutMs,omitempty"`
+	// BatchSize is the number of entries flushed to the sink in one call.
+	BatchSize int `json:"batchSize,omitempty"`
+	// BatchIntervalMS flushes a partial batch if it hasn't reached BatchSize in time.
+	BatchIntervalMS int `json:"batchIntervalMs,omitempty"`
+}
+
+func (b bufferingConfig) toConfig() Config {
+	policy := DropOldest
+	if b.DropPolicy == "block" {
+		policy = BlockWithTimeout
+	}
+	return Config{
+		BufferSize:    b.BufferSize,
+		DropPolicy:    policy,
+		BlockTimeout:  time.Duration(b.BlockTimeoutMS) * time.Millisecond,
+		BatchSize:     b.BatchSize,
+		BatchInterval: time.Duration(b.BatchIntervalMS) * time.Millisecond,
+	}
+}
+
+// KafkaForwardingConfig is the JSON shape of ForwardingConfig.Kafka.
+type KafkaForwardingConfig struct {
+	bufferingConfig
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+}
+
+// OTLPForwardingConfig is the JSON shape of ForwardingConfig.OTLP.
+type OTLPForwardingConfig struct {
+	bufferingConfig
+	Endpoint string `json:"endpoint"`
+	Insecure bool   `json:"insecure,omitempty"`
+}
+
+// BuildCores constructs a Core per backend configured in cfg (Kafka, OTLP, or both),
+// ready to be attached to the running logger with logging.AttachCore. encoder is
+// typically logging.JSONEncoder(), so forwarded entries match what's written to
+// stdout.
+func BuildCores(cfg ForwardingConfig, encoder zapcore.Encoder) ([]*Core, error) {
+	level := zapcore.InfoLevel
+	if cfg.Level != "" {
+		parsed, err := logging.FindLogLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("forwarding config: %w", err)
+		}
+		level = parsed
+	}
+
+	var cores []*Core
+
+	if cfg.Kafka != nil {
+		cores = append(cores, NewKafkaCore(level, encoder, cfg.Kafka.toConfig(), KafkaConfig{
+			Brokers: cfg.Kafka.Brokers,
+			Topic:   cfg.Kafka.Topic,
+		}))
+	}
+
+	if cfg.OTLP != nil {
+		core, err := NewOTLPCore(level, encoder, cfg.OTLP.toConfig(), OTLPConfig{
+			Endpoint: cfg.OTLP.Endpoint,
+			Insecure: cfg.OTLP.Insecure,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("build otlp forwarding core: %w", err)
+		}
+		cores = append(cores, core)
+	}
+
+	return cores, nil
+}
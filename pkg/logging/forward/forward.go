@@ -0,0 +1,213 @@
+// Package forward provides zapcore.Core implementations that asynchronously ship log
+// entries to an external broker (Kafka, an OTLP logs collector) so cloud deployments
+// can forward logs without running a sidecar log shipper.
+package forward
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DropPolicy controls what happens when a Core's buffer is full.
+type DropPolicy int
+
+const (
+	// DropOldest discards the oldest buffered entry to make room for the new one.
+	DropOldest DropPolicy = iota
+	// BlockWithTimeout blocks the logging call site for up to Config.BlockTimeout,
+	// dropping the new entry if the buffer doesn't free up in time.
+	BlockWithTimeout
+)
+
+// Config tunes the buffering and batching behavior shared by every sink in this
+// package.
+type Config struct {
+	// BufferSize is the number of encoded entries held in memory awaiting flush.
+	BufferSize int
+	// DropPolicy selects what happens when the buffer is full.
+	DropPolicy DropPolicy
+	// BlockTimeout is only used when DropPolicy is BlockWithTimeout.
+	BlockTimeout time.Duration
+	// BatchSize is the number of entries flushed to the sink in one call.
+	BatchSize int
+	// BatchInterval flushes a partial batch if it hasn't reached BatchSize in time.
+	BatchInterval time.Duration
+}
+
+// Metrics reports the lifetime counters for a Core.
+type Metrics struct {
+	Dropped uint64
+	Flushed uint64
+}
+
+// sink is implemented by each backend (Kafka, OTLP, ...).
+type sink interface {
+	send(batch [][]byte) error
+	Close() error
+}
+
+// Core is a zapcore.Core that encodes entries and hands them to a sink in batches,
+// fed by a bounded, non-blocking channel.
+type Core struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	fields  []zapcore.Field
+	cfg     Config
+	sink    sink
+
+	ch       chan []byte
+	dropped  uint64
+	flushed  uint64
+	done     chan struct{}
+	wg       *sync.WaitGroup
+	closeRef *sync.Once
+}
+
+func newCore(level zapcore.LevelEnabler, encoder zapcore.Encoder, cfg Config, s sink) *Core {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1024
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.BatchInterval <= 0 {
+		cfg.BatchInterval = time.Second
+	}
+
+	c := &Core{
+		LevelEnabler: level,
+		encoder:      encoder,
+		cfg:          cfg,
+		sink:         s,
+		ch:           make(chan []byte, cfg.BufferSize),
+		done:         make(chan struct{}),
+		wg:           &sync.WaitGroup{},
+		closeRef:     &sync.Once{},
+	}
+
+	c.wg.Add(1)
+	go c.loop()
+
+	return c
+}
+
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	return &Core{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      c.encoder,
+		fields:       append(append([]zapcore.Field(nil), c.fields...), fields...),
+		cfg:          c.cfg,
+		sink:         c.sink,
+		ch:           c.ch,
+		done:         c.done,
+		wg:           c.wg,
+		closeRef:     c.closeRef,
+	}
+}
+
+func (c *Core) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *Core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	line, err := c.encoder.EncodeEntry(entry, append(append([]zapcore.Field(nil), c.fields...), fields...))
+	if err != nil {
+		return err
+	}
+	buf := append([]byte(nil), line.Bytes()...)
+	line.Free()
+
+	select {
+	case c.ch <- buf:
+		return nil
+	default:
+	}
+
+	switch c.cfg.DropPolicy {
+	case DropOldest:
+		select {
+		case <-c.ch:
+			atomic.AddUint64(&c.dropped, 1)
+		default:
+		}
+		select {
+		case c.ch <- buf:
+		default:
+			atomic.AddUint64(&c.dropped, 1)
+		}
+	case BlockWithTimeout:
+		select {
+		case c.ch <- buf:
+		case <-time.After(c.cfg.BlockTimeout):
+			atomic.AddUint64(&c.dropped, 1)
+		}
+	}
+
+	return nil
+}
+
+// Sync is a no-op: flushing happens on the background batching loop.
+func (c *Core) Sync() error {
+	return nil
+}
+
+// Metrics returns the current dropped/flushed counters.
+func (c *Core) Metrics() Metrics {
+	return Metrics{
+		Dropped: atomic.LoadUint64(&c.dropped),
+		Flushed: atomic.LoadUint64(&c.flushed),
+	}
+}
+
+// Close stops the batching loop, flushes anything buffered, and closes the sink.
+func (c *Core) Close() error {
+	c.closeRef.Do(func() { close(c.done) })
+	c.wg.Wait()
+	return c.sink.Close()
+}
+
+func (c *Core) loop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.BatchInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, c.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := c.sink.send(batch); err == nil {
+			atomic.AddUint64(&c.flushed, uint64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case line := <-c.ch:
+			batch = append(batch, line)
+			if len(batch) >= c.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-c.done:
+			for {
+				select {
+				case line := <-c.ch:
+					batch = append(batch, line)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
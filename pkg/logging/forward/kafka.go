@@ -0,0 +1,40 @@
+package forward
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap/zapcore"
+)
+
+// KafkaConfig configures the Kafka sink.
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+}
+
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaCore returns a Core that forwards entries to a Kafka topic.
+func NewKafkaCore(level zapcore.LevelEnabler, encoder zapcore.Encoder, cfg Config, kafkaCfg KafkaConfig) *Core {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(kafkaCfg.Brokers...),
+		Topic:    kafkaCfg.Topic,
+		Balancer: &kafka.LeastBytes{},
+	}
+	return newCore(level, encoder, cfg, &kafkaSink{writer: writer})
+}
+
+func (s *kafkaSink) send(batch [][]byte) error {
+	msgs := make([]kafka.Message, len(batch))
+	for i, line := range batch {
+		msgs[i] = kafka.Message{Value: line}
+	}
+	return s.writer.WriteMessages(context.Background(), msgs...)
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,77 @@
+//go:build integration
+
+package forward_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	kafkatc "github.com/testcontainers/testcontainers-go/modules/kafka"
+	"go.uber.org/zap"
+
+	"github.com/wundergraph/wundergraph/pkg/logging"
+	"github.com/wundergraph/wundergraph/pkg/logging/forward"
+)
+
+// TestKafkaCoreForwardsEntries spins up a real Kafka broker via testcontainers and
+// verifies an entry written through a Core built by NewKafkaCore is observable on the
+// topic. Run with -tags=integration; requires a working Docker daemon.
+func TestKafkaCoreForwardsEntries(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := kafkatc.Run(ctx, "confluentinc/confluent-local:7.5.0")
+	if err != nil {
+		t.Fatalf("start kafka container: %v", err)
+	}
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	brokers, err := container.Brokers(ctx)
+	if err != nil {
+		t.Fatalf("resolve brokers: %v", err)
+	}
+
+	const topic = "wundergraph-logs"
+	const message = "hello from the kafka forwarding core"
+
+	core := forward.NewKafkaCore(zap.DebugLevel, logging.JSONEncoder(), forward.Config{
+		BufferSize:    16,
+		BatchSize:     1,
+		BatchInterval: 100 * time.Millisecond,
+	}, forward.KafkaConfig{Brokers: brokers, Topic: topic})
+	defer core.Close()
+
+	zap.New(core).Info(message)
+
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:  brokers,
+		Topic:    topic,
+		MinBytes: 1,
+		MaxBytes: 10e6,
+	})
+	defer reader.Close()
+
+	readCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	msg, err := reader.ReadMessage(readCtx)
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	if !strings.Contains(string(msg.Value), message) {
+		t.Fatalf("forwarded message = %s, want it to contain %q", msg.Value, message)
+	}
+
+	var flushed uint64
+	for i := 0; i < 50 && flushed == 0; i++ {
+		flushed = core.Metrics().Flushed
+		if flushed == 0 {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	if flushed == 0 {
+		t.Fatalf("expected Metrics().Flushed > 0 after forwarding")
+	}
+}
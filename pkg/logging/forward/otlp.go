@@ -0,0 +1,72 @@
+package forward
+
+import (
+	"context"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// OTLPConfig configures the OTLP logs gRPC sink.
+type OTLPConfig struct {
+	Endpoint string
+	Insecure bool
+}
+
+type otlpSink struct {
+	conn   *grpc.ClientConn
+	client collogspb.LogsServiceClient
+}
+
+// NewOTLPCore returns a Core that forwards entries to an OTLP logs collector over
+// gRPC.
+func NewOTLPCore(level zapcore.LevelEnabler, encoder zapcore.Encoder, cfg Config, otlpCfg OTLPConfig) (*Core, error) {
+	var opts []grpc.DialOption
+	if otlpCfg.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	conn, err := grpc.NewClient(otlpCfg.Endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &otlpSink{conn: conn, client: collogspb.NewLogsServiceClient(conn)}
+	return newCore(level, encoder, cfg, sink), nil
+}
+
+func (s *otlpSink) send(batch [][]byte) error {
+	records := make([]*logspb.LogRecord, len(batch))
+	for i, line := range batch {
+		records[i] = &logspb.LogRecord{
+			Body: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: string(line)},
+			},
+		}
+	}
+
+	req := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{
+			{
+				ScopeLogs: []*logspb.ScopeLogs{
+					{LogRecords: records},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := s.client.Export(ctx, req)
+	return err
+}
+
+func (s *otlpSink) Close() error {
+	return s.conn.Close()
+}
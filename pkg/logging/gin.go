@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GinMiddleware is the Gin-flavored equivalent of HTTPMiddleware, including
+// DebugHeader-gated per-request log capture.
+func GinMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		if IsDebugRequested(c.Request) {
+			DefaultCaptures.Begin(requestID)
+			defer DefaultCaptures.End(requestID)
+		}
+
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), RequestIDKey{}, requestID))
+
+		c.Next()
+
+		logger.With(WithRequestID(requestID)).Info("http request",
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("clientIP", c.ClientIP()),
+			zap.String("userAgent", c.Request.UserAgent()),
+			zap.Int("responseSize", c.Writer.Size()),
+		)
+	}
+}
+
+// GinRecovery is the Gin-flavored equivalent of RecoveryMiddleware.
+func GinRecovery(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.With(WithRequestIDFromContext(c.Request.Context())).Error("panic recovered",
+					zap.Any("panic", rec),
+					zap.Stack("stacktrace"),
+				)
+				c.AbortWithStatus(500)
+			}
+		}()
+		c.Next()
+	}
+}
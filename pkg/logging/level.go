@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelController guards a zap.AtomicLevel so the minimum log level emitted by a
+// logger built with New can be raised or lowered at runtime, e.g. from an admin
+// HTTP endpoint, without restarting the process.
+type LevelController struct {
+	level zap.AtomicLevel
+}
+
+// NewLevelController returns a LevelController seeded at lvl.
+func NewLevelController(lvl zapcore.Level) *LevelController {
+	return &LevelController{level: zap.NewAtomicLevelAt(lvl)}
+}
+
+// Level returns the currently configured level.
+func (c *LevelController) Level() zapcore.Level {
+	return c.level.Level()
+}
+
+// SetLevel updates the level applied to every logger sharing this controller.
+func (c *LevelController) SetLevel(lvl zapcore.Level) {
+	c.level.SetLevel(lvl)
+}
+
+// defaultLevelController backs the package-level Level/SetLevel/LevelHandler helpers
+// and the level used by New.
+var defaultLevelController = NewLevelController(zap.InfoLevel)
+
+// Level returns the level currently applied to loggers built by New.
+func Level() zapcore.Level {
+	return defaultLevelController.Level()
+}
+
+// SetLevel updates the level applied to loggers built by New.
+func SetLevel(lvl zapcore.Level) {
+	defaultLevelController.SetLevel(lvl)
+}
+
+type levelRequest struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an http.Handler suitable for mounting on an admin mux. A GET
+// reports the current level as {"level":"INFO"}; a PUT with the same shape updates it
+// (the level name is matched case-insensitively, so {"level":"debug"} works too).
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(levelRequest{Level: levelName(Level())})
+		case http.MethodPut:
+			var req levelRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lvl, err := FindLogLevel(req.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			SetLevel(lvl)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
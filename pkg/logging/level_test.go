@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevelControllerFiltersEmittedEntries(t *testing.T) {
+	lc := NewLevelController(zap.InfoLevel)
+	core, logs := observer.New(lc.level)
+	logger := zap.New(core)
+
+	logger.Debug("debug before raising level")
+	logger.Info("info before raising level")
+
+	if got := logs.Len(); got != 1 {
+		t.Fatalf("expected 1 entry at info level, got %d", got)
+	}
+
+	lc.SetLevel(zap.DebugLevel)
+	logger.Debug("debug after raising level")
+
+	if got := logs.Len(); got != 2 {
+		t.Fatalf("expected 2 entries after raising level to debug, got %d", got)
+	}
+	if got := lc.Level(); got != zap.DebugLevel {
+		t.Fatalf("Level() = %v, want debug", got)
+	}
+}
+
+func TestLevelHandlerRoundTrip(t *testing.T) {
+	SetLevel(zap.InfoLevel)
+	handler := LevelHandler()
+
+	getReq := httptest.NewRequest(http.MethodGet, "/admin/logs/level", nil)
+	getRR := httptest.NewRecorder()
+	handler.ServeHTTP(getRR, getReq)
+
+	var got levelRequest
+	if err := json.NewDecoder(getRR.Body).Decode(&got); err != nil {
+		t.Fatalf("decode GET response: %v", err)
+	}
+	if got.Level != "INFO" {
+		t.Fatalf("GET level = %q, want INFO", got.Level)
+	}
+
+	putReq := httptest.NewRequest(http.MethodPut, "/admin/logs/level", bytes.NewBufferString(`{"level":"debug"}`))
+	putRR := httptest.NewRecorder()
+	handler.ServeHTTP(putRR, putReq)
+
+	if putRR.Code != http.StatusNoContent {
+		t.Fatalf("PUT status = %d, want %d", putRR.Code, http.StatusNoContent)
+	}
+	if Level() != zap.DebugLevel {
+		t.Fatalf("Level() after PUT = %v, want debug", Level())
+	}
+}
@@ -2,10 +2,13 @@ package logging
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -15,6 +18,12 @@ import (
 const (
 	RequestIDHeader = "X-Request-Id"
 
+	// DebugHeader, when set to "true" on an inbound GraphQL request, asks the node to
+	// return everything logged for that request as debug metadata in the response.
+	DebugHeader = "X-Wg-Debug"
+
+	ringBufferDefaultCapacity = 1000
+
 	// logger field name must be aligned with fastify
 	requestIDField = "reqId"
 
@@ -33,8 +42,98 @@ const (
 
 type RequestIDKey struct{}
 
+// defaultCores is shared by every logger returned by New, so cores such as the
+// recent-logs ring buffer and per-request capture can be attached and detached at
+// runtime without rebuilding the logger.
+var defaultCores = NewLockedMultiCore()
+
+// DefaultCaptures backs per-request log capture for loggers built by New: attach a
+// request ID with Begin before a GraphQL operation starts, collect what was logged
+// for it with End once it finishes.
+var DefaultCaptures = NewCaptureRegistry()
+
+var defaultRingBuffer = NewRingBufferCore(ringBufferDefaultCapacity, zap.DebugLevel, zapJsonEncoder())
+
+func init() {
+	defaultCores.AttachCore(defaultRingBuffer)
+	defaultCores.AttachCore(DefaultCaptures.Core(zap.DebugLevel, zapJsonEncoder()))
+}
+
+var (
+	activeBaseCoreMu     sync.Mutex
+	activeBaseCoreDetach DetachFunc
+)
+
+// replaceBaseCore swaps out whatever base output core (stdout, rotating files,
+// sampled core, ...) a previous New/NewWithSinks/NewSampled call attached to
+// defaultCores, so constructing a logger more than once replaces the active output
+// instead of duplicating every entry onto an ever-growing set of cores.
+func replaceBaseCore(core zapcore.Core) {
+	activeBaseCoreMu.Lock()
+	defer activeBaseCoreMu.Unlock()
+
+	if activeBaseCoreDetach != nil {
+		activeBaseCoreDetach()
+	}
+	// Wrap core in a pointer so DetachCore can compare it: zapcore.NewTee returns a
+	// slice-backed core, and comparing two of those with == panics.
+	core = &struct{ zapcore.Core }{core}
+	activeBaseCoreDetach = defaultCores.AttachCore(core)
+}
+
+// AttachCore adds core to every logger built by New and returns a function that
+// detaches it again.
+func AttachCore(core zapcore.Core) DetachFunc {
+	return defaultCores.AttachCore(core)
+}
+
+// DetachCore removes core from every logger built by New.
+func DetachCore(core zapcore.Core) {
+	defaultCores.DetachCore(core)
+}
+
+// CapturedLogs stops capturing for the request ID stored in ctx (see
+// RequestIDFromContext) and returns everything logged for it, oldest first. Call it
+// from a handler, right before writing its response, to embed what was logged for
+// this request as debug metadata when the caller sent the DebugHeader.
+func CapturedLogs(ctx context.Context) []json.RawMessage {
+	return DefaultCaptures.End(RequestIDFromContext(ctx))
+}
+
+// IsDebugRequested reports whether r asked for per-request debug metadata via
+// DebugHeader.
+func IsDebugRequested(r *http.Request) bool {
+	return r.Header.Get(DebugHeader) == "true"
+}
+
+// RecentLogs returns the entries currently retained by the default recent-logs ring
+// buffer, oldest first.
+func RecentLogs() []json.RawMessage {
+	return defaultRingBuffer.Recent()
+}
+
+// RecentLogsHandler returns a ready-to-mount http.Handler serving RecentLogs as JSON,
+// e.g. at GET /admin/logs/recent.
+func RecentLogsHandler() http.Handler {
+	return newRecentLogsHandler(defaultRingBuffer)
+}
+
+// seedLevelOnce ensures level is only used to seed defaultLevelController the first
+// time a constructor in this package is called. After that, the level is meant to be
+// controlled exclusively through LevelController (SetLevel, LevelHandler), so a later
+// New/NewSampled/NewWithSinks call with a different level argument must not silently
+// undo a runtime change an operator already made.
+var seedLevelOnce sync.Once
+
+func seedLevel(level zapcore.Level) {
+	seedLevelOnce.Do(func() {
+		defaultLevelController.SetLevel(level)
+	})
+}
+
 func New(prettyLogging bool, debug bool, level zapcore.Level) *zap.Logger {
-	return newZapLogger(zapcore.AddSync(os.Stdout), prettyLogging, debug, level)
+	seedLevel(level)
+	return newZapLogger(zapcore.AddSync(os.Stdout), prettyLogging, debug, defaultLevelController.level)
 }
 
 func zapBaseEncoderConfig() zapcore.EncoderConfig {
@@ -44,6 +143,13 @@ func zapBaseEncoderConfig() zapcore.EncoderConfig {
 	return ec
 }
 
+// JSONEncoder returns the same JSON encoding used by New, so other cores (e.g. log
+// forwarders) preserve field names, reqId and the base fields added by
+// attachBaseFields.
+func JSONEncoder() zapcore.Encoder {
+	return zapJsonEncoder()
+}
+
 func zapJsonEncoder() zapcore.Encoder {
 	ec := zapBaseEncoderConfig()
 	ec.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
@@ -83,26 +189,31 @@ func attachBaseFields(logger *zap.Logger) *zap.Logger {
 	return logger
 }
 
-func newZapLogger(syncer zapcore.WriteSyncer, prettyLogging bool, debug bool, level zapcore.Level) *zap.Logger {
-	var encoder zapcore.Encoder
-	var zapOpts []zap.Option
-
+// encoderFor returns the console encoder when prettyLogging is set, otherwise the
+// JSON encoder shared by New and the other constructors in this package.
+func encoderFor(prettyLogging bool) zapcore.Encoder {
 	if prettyLogging {
-		encoder = newPrettyEncoder()
-	} else {
-		encoder = zapJsonEncoder()
+		return newPrettyEncoder()
 	}
+	return zapJsonEncoder()
+}
+
+func newZapLogger(syncer zapcore.WriteSyncer, prettyLogging bool, debug bool, level zapcore.LevelEnabler) *zap.Logger {
+	encoder := encoderFor(prettyLogging)
+	var zapOpts []zap.Option
 
 	if debug {
 		zapOpts = append(zapOpts, zap.AddStacktrace(zap.ErrorLevel))
 		zapOpts = append(zapOpts, zap.AddCaller())
 	}
 
-	zapLogger := zap.New(zapcore.NewCore(
+	replaceBaseCore(zapcore.NewCore(
 		encoder,
 		syncer,
 		level,
-	), zapOpts...)
+	))
+
+	zapLogger := zap.New(defaultCores, zapOpts...)
 
 	if prettyLogging {
 		return zapLogger
@@ -132,6 +243,28 @@ func FindLogLevel(logLevel string) (zapcore.Level, error) {
 	}
 }
 
+// levelName returns the spelling FindLogLevel accepts for level, so every level
+// FindLogLevel knows round-trips through FindLogLevel(levelName(level)) == level.
+// Other levels (e.g. DPanic) fall back to level.String(), which FindLogLevel rejects.
+func levelName(level zapcore.Level) string {
+	switch level {
+	case zap.DebugLevel:
+		return "DEBUG"
+	case zap.InfoLevel:
+		return "INFO"
+	case zap.WarnLevel:
+		return "WARNING"
+	case zap.ErrorLevel:
+		return "ERROR"
+	case zap.FatalLevel:
+		return "FATAL"
+	case zap.PanicLevel:
+		return "PANIC"
+	default:
+		return level.String()
+	}
+}
+
 func RequestIDFromContext(ctx context.Context) string {
 	if ctx == nil {
 		return ""
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// HTTPMiddleware returns middleware that logs one structured entry per request with
+// status, latency, method, path, client IP, user agent and response size. It
+// generates a request ID (honoring an inbound X-Request-Id header), stores it in the
+// request context under RequestIDKey, echoes it back in the response header, and
+// logs the access entry through a child logger enriched with WithRequestID. No logger
+// is placed in the context; handlers read the ID with RequestIDFromContext. When
+// the caller sends DebugHeader: true, everything logged for the request is captured;
+// the handler can collect it with CapturedLogs before writing its response.
+func HTTPMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := r.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			if IsDebugRequested(r) {
+				DefaultCaptures.Begin(requestID)
+				defer DefaultCaptures.End(requestID)
+			}
+
+			ctx := context.WithValue(r.Context(), RequestIDKey{}, requestID)
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			logger.With(WithRequestID(requestID)).Info("http request",
+				zap.Int("status", sw.status),
+				zap.Duration("latency", time.Since(start)),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("clientIP", clientIP(r)),
+				zap.String("userAgent", r.UserAgent()),
+				zap.Int("responseSize", sw.size),
+			)
+		})
+	}
+}
+
+// RecoveryMiddleware returns middleware that recovers panics from the next handler,
+// logs them with a stacktrace at Error level, and responds with 500.
+func RecoveryMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					logger.With(WithRequestIDFromContext(r.Context())).Error("panic recovered",
+						zap.Any("panic", rec),
+						zap.Stack("stacktrace"),
+					)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		return fwd
+	}
+	return r.RemoteAddr
+}
+
+// statusWriter wraps http.ResponseWriter to capture the status code and number of
+// bytes written, for access logging.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
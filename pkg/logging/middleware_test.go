@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestHTTPMiddlewareRequestIDRoundTrip(t *testing.T) {
+	var gotCtxID string
+	handler := HTTPMiddleware(zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCtxID = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("generates an ID when none is supplied", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		respID := rr.Header().Get(RequestIDHeader)
+		if respID == "" {
+			t.Fatalf("expected a generated request ID in the response header")
+		}
+		if gotCtxID != respID {
+			t.Fatalf("context request ID %q != response header %q", gotCtxID, respID)
+		}
+	})
+
+	t.Run("honors an inbound request ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, "fixed-id")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if got := rr.Header().Get(RequestIDHeader); got != "fixed-id" {
+			t.Fatalf("response request ID = %q, want %q", got, "fixed-id")
+		}
+		if gotCtxID != "fixed-id" {
+			t.Fatalf("context request ID = %q, want %q", gotCtxID, "fixed-id")
+		}
+	})
+}
+
+func TestGinMiddlewareRequestIDRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var gotCtxID string
+	router := gin.New()
+	router.Use(GinMiddleware(zap.NewNop()))
+	router.GET("/", func(c *gin.Context) {
+		gotCtxID = RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "fixed-id")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get(RequestIDHeader); got != "fixed-id" {
+		t.Fatalf("response request ID = %q, want %q", got, "fixed-id")
+	}
+	if gotCtxID != "fixed-id" {
+		t.Fatalf("context request ID = %q, want %q", gotCtxID, "fixed-id")
+	}
+}
+
+func TestRecoveryMiddlewareRecoversPanic(t *testing.T) {
+	handler := RecoveryMiddleware(zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLockedMultiCoreAttachDetach(t *testing.T) {
+	mc := NewLockedMultiCore()
+	obsCore, logs := observer.New(zap.InfoLevel)
+	detach := mc.AttachCore(obsCore)
+
+	logger := zap.New(mc)
+	logger.Info("one")
+	if got := logs.Len(); got != 1 {
+		t.Fatalf("expected 1 entry after attach, got %d", got)
+	}
+
+	detach()
+	logger.Info("two")
+	if got := logs.Len(); got != 1 {
+		t.Fatalf("expected detach to stop further entries, got %d", got)
+	}
+}
+
+func TestLockedMultiCoreDetachIsIdempotent(t *testing.T) {
+	mc := NewLockedMultiCore()
+	obsCore, _ := observer.New(zap.InfoLevel)
+	detach := mc.AttachCore(obsCore)
+
+	detach()
+	detach() // must not panic or detach an unrelated core
+}
+
+// TestLockedMultiCoreWithDelegatesToLiveCores is a regression test for a bug where
+// With took a snapshot of m.cores at call time: a logger built before a core was
+// attached would never write to it. Check must re-read the live core list on every
+// call instead.
+func TestLockedMultiCoreWithDelegatesToLiveCores(t *testing.T) {
+	mc := NewLockedMultiCore()
+	logger := zap.New(mc).With(zap.String("component", "test"))
+
+	logger.Info("before attach")
+
+	obsCore, logs := observer.New(zap.InfoLevel)
+	mc.AttachCore(obsCore)
+
+	logger.Info("after attach")
+
+	if got := logs.Len(); got != 1 {
+		t.Fatalf("expected the core attached after With was called to receive entries, got %d", got)
+	}
+
+	entry := logs.All()[0]
+	if entry.Message != "after attach" {
+		t.Fatalf("entry message = %q, want %q", entry.Message, "after attach")
+	}
+
+	found := false
+	for _, f := range entry.Context {
+		if f.Key == "component" && f.String == "test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected bound field component=test on the entry, got %+v", entry.Context)
+	}
+}
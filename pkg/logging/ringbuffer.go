@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// RingBufferCore is a zapcore.Core that keeps the last N encoded entries in memory,
+// so an admin endpoint can serve a recent log tail without a central log system.
+type RingBufferCore struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	fields  []zapcore.Field
+	buf     *ringBuffer
+}
+
+// NewRingBufferCore returns a RingBufferCore retaining at most capacity entries at
+// or above level, encoded with encoder.
+func NewRingBufferCore(capacity int, level zapcore.LevelEnabler, encoder zapcore.Encoder) *RingBufferCore {
+	return &RingBufferCore{
+		LevelEnabler: level,
+		encoder:      encoder,
+		buf:          newRingBuffer(capacity),
+	}
+}
+
+func (c *RingBufferCore) With(fields []zapcore.Field) zapcore.Core {
+	return &RingBufferCore{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      c.encoder,
+		fields:       append(append([]zapcore.Field(nil), c.fields...), fields...),
+		buf:          c.buf,
+	}
+}
+
+func (c *RingBufferCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *RingBufferCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	line, err := c.encoder.EncodeEntry(entry, append(append([]zapcore.Field(nil), c.fields...), fields...))
+	if err != nil {
+		return err
+	}
+	c.buf.push(line.Bytes())
+	line.Free()
+	return nil
+}
+
+func (c *RingBufferCore) Sync() error {
+	return nil
+}
+
+// Recent returns the currently retained entries, oldest first.
+func (c *RingBufferCore) Recent() []json.RawMessage {
+	return c.buf.recent()
+}
+
+// newRecentLogsHandler returns an http.Handler that serves core's retained entries as
+// a JSON array, intended to be mounted at an admin path such as /admin/logs/recent.
+func newRecentLogsHandler(core *RingBufferCore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(core.Recent())
+	})
+}
+
+type ringBuffer struct {
+	mu       sync.Mutex
+	capacity int
+	entries  []json.RawMessage
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{capacity: capacity}
+}
+
+func (b *ringBuffer) push(line []byte) {
+	entry := append(json.RawMessage(nil), line...)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries = append(b.entries, entry)
+	if over := len(b.entries) - b.capacity; over > 0 {
+		b.entries = b.entries[over:]
+	}
+}
+
+func (b *ringBuffer) recent() []json.RawMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make([]json.RawMessage, len(b.entries))
+	copy(out, b.entries)
+	return out
+}
@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig tunes zapcore's log sampling: after Initial entries with the same
+// level and message in a Tick window, only every Thereafter-th one is logged. Warn
+// and above are never sampled.
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// NewSampled is like New but wraps the core in a sampler tuned by cfg, protecting the
+// hot path when a downstream failure causes a high-QPS endpoint to log in a loop.
+// Entries at Warn level and above bypass the sampler. Once per Tick, the number of
+// entries the sampler dropped is reported at Info level.
+func NewSampled(prettyLogging bool, debug bool, level zapcore.Level, cfg SamplingConfig) *zap.Logger {
+	seedLevel(level)
+
+	encoder := encoderFor(prettyLogging)
+	syncer := zapcore.AddSync(os.Stdout)
+
+	sampledLevel, passthroughLevel := samplingLevelSplit(defaultLevelController.level)
+
+	var dropped uint64
+	sampledCore := zapcore.NewSamplerWithOptions(
+		zapcore.NewCore(encoder, syncer, sampledLevel),
+		cfg.Tick, cfg.Initial, cfg.Thereafter,
+		zapcore.SamplerHook(func(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+			if decision&zapcore.LogDropped > 0 {
+				atomic.AddUint64(&dropped, 1)
+			}
+		}),
+	)
+	passthroughCore := zapcore.NewCore(encoder, syncer, passthroughLevel)
+
+	replaceBaseCore(zapcore.NewTee(sampledCore, passthroughCore))
+
+	var zapOpts []zap.Option
+	if debug {
+		zapOpts = append(zapOpts, zap.AddStacktrace(zap.ErrorLevel), zap.AddCaller())
+	}
+
+	zapLogger := zap.New(defaultCores, zapOpts...)
+	if !prettyLogging {
+		zapLogger = attachBaseFields(zapLogger)
+	}
+
+	restartSamplingBudgetReporter(zapLogger, cfg.Tick, &dropped)
+
+	return zapLogger
+}
+
+// samplingLevelSplit derives the two level enablers NewSampled tees the base core
+// into from levelEnabler: sampled carries everything below Warn, passthrough carries
+// Warn and above, so bursts of low-severity logging can be sampled without ever
+// dropping a warning or error.
+func samplingLevelSplit(levelEnabler zapcore.LevelEnabler) (sampled, passthrough zapcore.LevelEnabler) {
+	sampled = zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return levelEnabler.Enabled(l) && l < zap.WarnLevel
+	})
+	passthrough = zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return levelEnabler.Enabled(l) && l >= zap.WarnLevel
+	})
+	return sampled, passthrough
+}
+
+var (
+	samplingBudgetReporterMu   sync.Mutex
+	samplingBudgetReporterStop chan struct{}
+)
+
+// restartSamplingBudgetReporter stops the reporting goroutine started by a previous
+// NewSampled call, if any, before starting a new one, so repeated calls don't leak a
+// ticker goroutine per call.
+func restartSamplingBudgetReporter(logger *zap.Logger, tick time.Duration, dropped *uint64) {
+	samplingBudgetReporterMu.Lock()
+	defer samplingBudgetReporterMu.Unlock()
+
+	if samplingBudgetReporterStop != nil {
+		close(samplingBudgetReporterStop)
+	}
+	stop := make(chan struct{})
+	samplingBudgetReporterStop = stop
+
+	go reportSamplingBudget(logger, tick, dropped, stop)
+}
+
+func reportSamplingBudget(logger *zap.Logger, tick time.Duration, dropped *uint64, stop chan struct{}) {
+	if tick <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := atomic.SwapUint64(dropped, 0); n > 0 {
+				logger.Info("sampling burst budget exceeded", zap.Uint64("droppedEntries", n))
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestSamplingLevelSplitExemptsWarnAndAbove pins the rule documented on SamplingConfig:
+// Warn and above must never land in the sampled core, only in the passthrough one.
+func TestSamplingLevelSplitExemptsWarnAndAbove(t *testing.T) {
+	sampled, passthrough := samplingLevelSplit(zap.NewAtomicLevelAt(zap.DebugLevel))
+
+	cases := []struct {
+		level           zapcore.Level
+		wantSampled     bool
+		wantPassthrough bool
+	}{
+		{zap.DebugLevel, true, false},
+		{zap.InfoLevel, true, false},
+		{zap.WarnLevel, false, true},
+		{zap.ErrorLevel, false, true},
+	}
+
+	for _, c := range cases {
+		if got := sampled.Enabled(c.level); got != c.wantSampled {
+			t.Errorf("sampled.Enabled(%v) = %v, want %v", c.level, got, c.wantSampled)
+		}
+		if got := passthrough.Enabled(c.level); got != c.wantPassthrough {
+			t.Errorf("passthrough.Enabled(%v) = %v, want %v", c.level, got, c.wantPassthrough)
+		}
+	}
+}
+
+// TestSamplingLevelSplitHonorsUnderlyingLevel checks both halves of the split still
+// defer to the level passed in, not just the Warn threshold.
+func TestSamplingLevelSplitHonorsUnderlyingLevel(t *testing.T) {
+	sampled, passthrough := samplingLevelSplit(zap.NewAtomicLevelAt(zap.ErrorLevel))
+
+	if sampled.Enabled(zap.InfoLevel) {
+		t.Fatalf("expected Info to be disabled once the underlying level is raised to Error")
+	}
+	if passthrough.Enabled(zap.WarnLevel) {
+		t.Fatalf("expected Warn to be disabled once the underlying level is raised to Error")
+	}
+	if !passthrough.Enabled(zap.ErrorLevel) {
+		t.Fatalf("expected Error to remain enabled")
+	}
+}
+
+// TestRestartSamplingBudgetReporterStopsPreviousGoroutine is a regression test for a
+// goroutine leak: calling NewSampled (and so restartSamplingBudgetReporter) twice used
+// to start a second reporting goroutine without ever stopping the first.
+func TestRestartSamplingBudgetReporterStopsPreviousGoroutine(t *testing.T) {
+	logger := zap.NewNop()
+	var dropped uint64
+
+	restartSamplingBudgetReporter(logger, time.Hour, &dropped)
+	first := samplingBudgetReporterStop
+
+	restartSamplingBudgetReporter(logger, time.Hour, &dropped)
+
+	select {
+	case <-first:
+		// closed, as expected.
+	default:
+		t.Fatalf("expected the first stop channel to be closed when restarting")
+	}
+
+	if samplingBudgetReporterStop == first {
+		t.Fatalf("expected restarting to install a new stop channel")
+	}
+}